@@ -1,7 +1,11 @@
 package com.jfrog.testing;
 
+import com.fasterxml.jackson.databind.JsonNode;
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.apache.commons.codec.digest.DigestUtils;
 import org.apache.commons.collections4.CollectionUtils;
 import org.apache.commons.io.IOUtils;
+import org.apache.commons.io.output.NullOutputStream;
 import org.apache.commons.lang.ArrayUtils;
 import org.apache.commons.lang.text.StrSubstitutor;
 import org.apache.commons.lang3.StringUtils;
@@ -11,7 +15,10 @@ import org.apache.log4j.Logger;
 import org.jfrog.artifactory.client.Artifactory;
 import org.jfrog.artifactory.client.ArtifactoryClientBuilder;
 import org.jfrog.artifactory.client.ArtifactoryRequest;
+import org.jfrog.artifactory.client.ArtifactoryResponse;
+import org.jfrog.artifactory.client.PropertiesHandler;
 import org.jfrog.artifactory.client.RepositoryHandle;
+import org.jfrog.artifactory.client.UploadableArtifact;
 import org.jfrog.artifactory.client.impl.ArtifactoryRequestImpl;
 import org.jfrog.artifactory.client.model.LightweightRepository;
 import org.jfrog.artifactory.client.model.RepoPath;
@@ -20,24 +27,46 @@ import org.jfrog.build.api.Artifact;
 import org.jfrog.build.api.Build;
 import org.jfrog.build.api.Dependency;
 import org.jfrog.build.api.Module;
+import org.jfrog.build.api.builder.ArtifactBuilder;
+import org.jfrog.build.api.builder.BuildInfoBuilder;
+import org.jfrog.build.api.builder.DependencyBuilder;
+import org.jfrog.build.api.builder.ModuleBuilder;
 import org.jfrog.build.api.util.NullLog;
 import org.jfrog.build.extractor.clientConfiguration.client.ArtifactoryBuildInfoClient;
 
+import java.io.ByteArrayInputStream;
 import java.io.IOException;
 import java.io.InputStream;
+import java.io.OutputStream;
+import java.io.RandomAccessFile;
 import java.io.UnsupportedEncodingException;
+import java.net.URLDecoder;
 import java.net.URLEncoder;
 import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
 import java.nio.file.Path;
 import java.nio.file.Paths;
+import java.text.ParseException;
+import java.text.SimpleDateFormat;
+import java.util.ArrayList;
 import java.util.Arrays;
+import java.util.Collection;
+import java.util.Collections;
+import java.util.Date;
 import java.util.List;
+import java.util.Map;
 import java.util.Properties;
+import java.util.Random;
 import java.util.Set;
+import java.util.UUID;
 import java.util.concurrent.TimeUnit;
+import java.util.concurrent.atomic.AtomicInteger;
+import java.util.concurrent.atomic.AtomicLong;
+import java.util.concurrent.atomic.AtomicReference;
 import java.util.regex.Matcher;
 import java.util.regex.Pattern;
 import java.util.stream.Collectors;
+import java.util.stream.StreamSupport;
 
 import static org.jfrog.artifactory.client.model.impl.RepositoryTypeImpl.*;
 import static org.junit.Assert.*;
@@ -62,7 +91,18 @@ public class IntegrationTestsHelper implements AutoCloseable {
     public static final long repoTimestamp = System.currentTimeMillis();
     private static final Logger log = LogManager.getLogger(IntegrationTestsHelper.class);
 
-    private static final Pattern REPO_PATTERN = Pattern.compile("^jfrog-rt-tests(-\\w*)+-(\\d*)$");
+    // Matches the creation timestamp suffix of test resource names. Limited to 18 digits to always fit in a long.
+    private static final Pattern NAME_TIMESTAMP_PATTERN = Pattern.compile("-(\\d{1,18})$");
+
+    // Number of distinct folder names in each level of the generated test data
+    private static final int TEST_DATA_FOLDERS_PER_LEVEL = 10;
+    // Size of the chunks written when generating large random files
+    private static final int LARGE_FILE_BUFFER_SIZE = 1024 * 1024;
+    // Interval between two attempts when waiting for a condition
+    private static final long POLLING_INTERVAL_MILLIS = 1000;
+    // Interval between two storage summary calculations when waiting for the binaries count
+    private static final long STORAGE_CALCULATION_INTERVAL_MILLIS = 30000;
+    private static final ObjectMapper OBJECT_MAPPER = new ObjectMapper();
 
     private final ArtifactoryBuildInfoClient buildInfoClient;
     private final Artifactory artifactoryClient;
@@ -123,28 +163,40 @@ public class IntegrationTestsHelper implements AutoCloseable {
      * Clean up old test repositories.
      */
     public void cleanUpArtifactory() {
-        Arrays.asList(LOCAL, REMOTE, VIRTUAL).forEach(this::cleanUpRepositoryType);
+        cleanUpRepositories(TestRepository.REPO_PREFIX, 24);
+    }
+
+    /**
+     * Clean up old test repositories with the specified repository key prefix.
+     * Only repositories with keys ending with their creation timestamp in millis are deleted, as returned from {@link #getRepoKey(TestRepository)}.
+     *
+     * @param repoKeyPrefix - The prefix of the repository keys to delete
+     * @param maxAgeHours   - Delete repositories created at least this number of hours ago
+     */
+    public void cleanUpRepositories(String repoKeyPrefix, long maxAgeHours) {
+        if (StringUtils.isBlank(repoKeyPrefix)) {
+            throw new IllegalArgumentException("A repository key prefix is required for cleaning up repositories");
+        }
+        Arrays.asList(LOCAL, REMOTE, VIRTUAL).forEach(repositoryType -> cleanUpRepositoryType(repositoryType, repoKeyPrefix, maxAgeHours));
     }
 
     /**
      * Clean up old tests repositories with the specified type - Local, Remote or Virtual
      *
      * @param repositoryType - The repository type to delete
+     * @param repoKeyPrefix  - The prefix of the repository keys to delete
+     * @param maxAgeHours    - Delete repositories created at least this number of hours ago
      */
-    private void cleanUpRepositoryType(RepositoryType repositoryType) {
+    private void cleanUpRepositoryType(RepositoryType repositoryType, String repoKeyPrefix, long maxAgeHours) {
         artifactoryClient.repositories().list(repositoryType).stream()
                 // Get repository key
                 .map(LightweightRepository::getKey)
 
                 // Match repository
-                .map(REPO_PATTERN::matcher)
-                .filter(Matcher::matches)
-
-                // Filter repositories newer than 2 hours
-                .filter(this::isRepositoryOld)
+                .filter(repoKey -> repoKey.startsWith(repoKeyPrefix))
 
-                // Get repository key
-                .map(Matcher::group)
+                // Filter repositories newer than maxAgeHours
+                .filter(repoKey -> isNameOld(repoKey, maxAgeHours))
 
                 // Create repository handle
                 .map(artifactoryClient::repository)
@@ -154,14 +206,142 @@ public class IntegrationTestsHelper implements AutoCloseable {
     }
 
     /**
-     * Return true if the repository was created more than 2 hours ago.
+     * Return true if the name ends with a creation timestamp in millis, which is at least maxAgeHours ago.
+     *
+     * @param name        - Repository key or username
+     * @param maxAgeHours - Maximum age in hours
+     * @return true if the name ends with a creation timestamp at least maxAgeHours ago
+     */
+    private boolean isNameOld(String name, long maxAgeHours) {
+        Matcher timestampMatcher = NAME_TIMESTAMP_PATTERN.matcher(name);
+        return timestampMatcher.find() && isOld(Long.parseLong(timestampMatcher.group(1)), maxAgeHours);
+    }
+
+    /**
+     * Clean up old test builds with the specified build name prefix.
+     *
+     * @param buildNamePrefix - The prefix of the build names to delete
+     * @param maxAgeHours     - Delete builds last started at least this number of hours ago
+     * @throws IOException if failed to list or delete the builds
+     */
+    public void cleanUpBuilds(String buildNamePrefix, long maxAgeHours) throws IOException {
+        if (StringUtils.isBlank(buildNamePrefix)) {
+            throw new IllegalArgumentException("A build name prefix is required for cleaning up builds");
+        }
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.GET)
+                .apiUrl("api/build"));
+        // Artifactory returns 404 if there are no builds
+        if (response.getStatusLine().getStatusCode() == 404) {
+            return;
+        }
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to list builds: " + response.getStatusLine());
+        }
+        for (JsonNode build : OBJECT_MAPPER.readTree(response.getRawBody()).path("builds")) {
+            String buildName = URLDecoder.decode(StringUtils.removeStart(build.path("uri").asText(), "/"), "UTF-8");
+            if (buildName.startsWith(buildNamePrefix) && isBuildOld(build.path("lastStarted").asText(), maxAgeHours)) {
+                deleteBuild(buildName);
+                log.info("Build " + buildName + " deleted");
+            }
+        }
+    }
+
+    /**
+     * Return true if the build was last started at least maxAgeHours ago.
      *
-     * @param repoMatcher - Repo regex matcher on REPO_PATTERN
-     * @return true if the repository was created more than 2 hours ago
+     * @param lastStarted - The build last started time, as returned from Artifactory
+     * @param maxAgeHours - Maximum age in hours
+     * @return true if the build was last started at least maxAgeHours ago
      */
-    private boolean isRepositoryOld(Matcher repoMatcher) {
-        long repoTimestamp = Long.parseLong(repoMatcher.group(2));
-        return TimeUnit.MILLISECONDS.toHours(System.currentTimeMillis() - repoTimestamp) >= 24;
+    private boolean isBuildOld(String lastStarted, long maxAgeHours) {
+        try {
+            return isOld(new SimpleDateFormat(Build.STARTED_FORMAT).parse(lastStarted).getTime(), maxAgeHours);
+        } catch (ParseException e) {
+            return false;
+        }
+    }
+
+    /**
+     * Clean up old test users with the specified username prefix.
+     * Artifactory doesn't expose the users creation time, so only users named {@code <prefix>...-<creation timestamp in millis>}
+     * are deleted, the same way test repositories are named.
+     *
+     * @param usernamePrefix - The prefix of the usernames to delete
+     * @param maxAgeHours    - Delete users created at least this number of hours ago
+     * @throws IOException if failed to list or delete the users
+     */
+    public void cleanUpUsers(String usernamePrefix, long maxAgeHours) throws IOException {
+        if (StringUtils.isBlank(usernamePrefix)) {
+            throw new IllegalArgumentException("A username prefix is required for cleaning up users");
+        }
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.GET)
+                .apiUrl("api/security/users"));
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to list users: " + response.getStatusLine());
+        }
+        for (JsonNode user : OBJECT_MAPPER.readTree(response.getRawBody())) {
+            String username = user.path("name").asText();
+            if (!username.startsWith(usernamePrefix) || !isNameOld(username, maxAgeHours)) {
+                continue;
+            }
+            ArtifactoryResponse deleteResponse = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                    .method(ArtifactoryRequest.Method.DELETE)
+                    .apiUrl("api/security/users/" + encodePathSegment(username)));
+            if (!deleteResponse.isSuccessResponse()) {
+                throw new IOException("Failed to delete user " + username + ": " + deleteResponse.getStatusLine());
+            }
+            log.info("User " + username + " deleted");
+        }
+    }
+
+    /**
+     * Revoke old access tokens issued to test users with the specified username prefix.
+     *
+     * @param usernamePrefix - The prefix of the usernames, whose tokens to revoke
+     * @param maxAgeHours    - Revoke tokens issued at least this number of hours ago
+     * @throws IOException if failed to list or revoke the tokens
+     */
+    public void cleanUpTokens(String usernamePrefix, long maxAgeHours) throws IOException {
+        if (StringUtils.isBlank(usernamePrefix)) {
+            throw new IllegalArgumentException("A username prefix is required for cleaning up access tokens");
+        }
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.GET)
+                .apiUrl("api/security/token"));
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to list access tokens: " + response.getStatusLine());
+        }
+        for (JsonNode token : OBJECT_MAPPER.readTree(response.getRawBody()).path("tokens")) {
+            // The token subject is of the form: <service-id>/users/<username>
+            String username = StringUtils.substringAfterLast(token.path("subject").asText(), "/users/");
+            long issuedAt = TimeUnit.SECONDS.toMillis(token.path("issued_at").asLong());
+            if (!username.startsWith(usernamePrefix) || !isOld(issuedAt, maxAgeHours)) {
+                continue;
+            }
+            String tokenId = token.path("token_id").asText();
+            ArtifactoryResponse revokeResponse = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                    .method(ArtifactoryRequest.Method.POST)
+                    .requestType(ArtifactoryRequest.ContentType.URLENC)
+                    .apiUrl("api/security/token/revoke")
+                    .requestBody("token_id=" + URLEncoder.encode(tokenId, "UTF-8")));
+            if (!revokeResponse.isSuccessResponse()) {
+                throw new IOException("Failed to revoke access token " + tokenId + ": " + revokeResponse.getStatusLine());
+            }
+            log.info("Access token " + tokenId + " of user " + username + " revoked");
+        }
+    }
+
+    /**
+     * Return true if the timestamp is at least maxAgeHours ago.
+     *
+     * @param timestampMillis - Timestamp in milliseconds
+     * @param maxAgeHours     - Maximum age in hours
+     * @return true if the timestamp is at least maxAgeHours ago
+     */
+    private static boolean isOld(long timestampMillis, long maxAgeHours) {
+        return TimeUnit.MILLISECONDS.toHours(System.currentTimeMillis() - timestampMillis) >= maxAgeHours;
     }
 
     /**
@@ -229,6 +409,149 @@ public class IntegrationTestsHelper implements AutoCloseable {
         artifactoryClient.repository(repoKey).upload(source.getFileName().toString(), source.toFile()).doUpload();
     }
 
+    /**
+     * Populate a repository with generated artifacts.
+     * The folders layout and the artifacts content are derived from the seed, so using the same seed yields the same repository content.
+     *
+     * @param repoKey        - Repository key
+     * @param artifactsCount - Number of artifacts to deploy
+     * @param artifactSize   - Size of each artifact in bytes
+     * @param folderDepth    - Number of folders above each artifact
+     * @param properties     - Properties to set on each artifact, or null for no properties
+     * @param seed           - Seed for generating the folders layout and the artifacts content
+     * @return paths of the deployed artifacts, relative to the repository root
+     */
+    public List<String> populateRepo(String repoKey, int artifactsCount, int artifactSize, int folderDepth, Map<String, String> properties, long seed) {
+        Random random = new Random(seed);
+        List<String> artifactPaths = new ArrayList<>();
+        for (int i = 0; i < artifactsCount; i++) {
+            StringBuilder artifactPath = new StringBuilder();
+            for (int level = 0; level < folderDepth; level++) {
+                artifactPath.append("folder-").append(random.nextInt(TEST_DATA_FOLDERS_PER_LEVEL)).append("/");
+            }
+            artifactPath.append("artifact-").append(i).append(".bin");
+
+            byte[] content = new byte[artifactSize];
+            random.nextBytes(content);
+            UploadableArtifact artifact = artifactoryClient.repository(repoKey).upload(artifactPath.toString(), new ByteArrayInputStream(content));
+            if (properties != null) {
+                properties.forEach((key, value) -> artifact.withProperty(key, value));
+            }
+            artifact.doUpload();
+            artifactPaths.add(artifactPath.toString());
+        }
+        return artifactPaths;
+    }
+
+    /**
+     * Create a file of an arbitrary size, for testing multipart and resumable uploads.
+     * Use {@link #uploadFile(Path, String)} to deploy the created file.
+     *
+     * @param target - The file to create. An existing file is overwritten
+     * @param size   - File size in bytes. Must not be negative
+     * @param sparse - If true, create a sparse file of zeros without writing its content to the disk
+     * @param seed   - Seed for generating the file content. Ignored for sparse files
+     * @throws IOException if failed to create the file
+     */
+    public static void createLargeFile(Path target, long size, boolean sparse, long seed) throws IOException {
+        if (size < 0) {
+            throw new IllegalArgumentException("File size must not be negative: " + size);
+        }
+        if (sparse) {
+            try (RandomAccessFile file = new RandomAccessFile(target.toFile(), "rw")) {
+                // Discard the existing content, if the file already exists
+                file.setLength(0);
+                file.setLength(size);
+            }
+            return;
+        }
+        Random random = new Random(seed);
+        byte[] buffer = new byte[LARGE_FILE_BUFFER_SIZE];
+        try (OutputStream outputStream = Files.newOutputStream(target)) {
+            for (long remaining = size; remaining > 0; remaining -= buffer.length) {
+                random.nextBytes(buffer);
+                outputStream.write(buffer, 0, (int) Math.min(buffer.length, remaining));
+            }
+        }
+    }
+
+    /**
+     * Set properties on seeded artifacts.
+     * Properties are the custom metadata of Artifactory items, so they also serve for seeding custom metadata.
+     * Artifacts mapped to empty properties are skipped.
+     *
+     * @param repoKey        - Repository key
+     * @param propertiesSpec - Map of artifact path to the properties to set on the artifact
+     */
+    public void seedProperties(String repoKey, Map<String, Map<String, String>> propertiesSpec) {
+        propertiesSpec.forEach((artifactPath, properties) -> {
+            if (properties.isEmpty()) {
+                return;
+            }
+            PropertiesHandler propertiesHandler = artifactoryClient.repository(repoKey).file(artifactPath).properties();
+            properties.forEach((key, value) -> propertiesHandler.addProperty(key, value));
+            propertiesHandler.doSet();
+        });
+    }
+
+    /**
+     * Download seeded artifacts to populate their download statistics.
+     *
+     * @param repoKey       - Repository key
+     * @param downloadsSpec - Map of artifact path to the number of times to download the artifact
+     * @throws IOException if failed to download an artifact
+     */
+    public void seedDownloadStats(String repoKey, Map<String, Integer> downloadsSpec) throws IOException {
+        for (Map.Entry<String, Integer> entry : downloadsSpec.entrySet()) {
+            for (int i = 0; i < entry.getValue(); i++) {
+                downloadAndDiscard(repoKey, entry.getKey());
+            }
+        }
+    }
+
+    /**
+     * Populate the cache of a temporary remote repository by resolving artifacts through it.
+     *
+     * @param repository    - The remote test repository
+     * @param artifactPaths - Paths of the artifacts to resolve, relative to the repository root
+     * @throws IOException if failed to resolve an artifact
+     */
+    public void warmUpRemoteRepoCache(TestRepository repository, Collection<String> artifactPaths) throws IOException {
+        String repoKey = getRepoKey(repository);
+        for (String artifactPath : artifactPaths) {
+            downloadAndDiscard(repoKey, artifactPath);
+        }
+        log.info("Repository " + repoKey + " cache populated with " + artifactPaths.size() + " artifacts");
+    }
+
+    /**
+     * Download an artifact and discard its content.
+     *
+     * @param repoKey      - Repository key
+     * @param artifactPath - Artifact path, relative to the repository root
+     * @throws IOException if failed to download the artifact
+     */
+    private void downloadAndDiscard(String repoKey, String artifactPath) throws IOException {
+        try (InputStream inputStream = artifactoryClient.repository(repoKey).download(artifactPath).doDownload()) {
+            IOUtils.copy(inputStream, NullOutputStream.NULL_OUTPUT_STREAM);
+        }
+    }
+
+    /**
+     * Send a request with a unique URL to Artifactory, to use as a barrier when reading the request log.
+     * The request targets a repository that doesn't exist, so Artifactory is expected to reject it.
+     *
+     * @return the unique repository key in the sent request URL
+     * @throws IOException if failed to send the request
+     */
+    public String sendSentinelRequest() throws IOException {
+        String sentinel = "jfrog-rt-tests-sentinel-" + UUID.randomUUID();
+        artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.GET)
+                .apiUrl("api/repositories/" + sentinel));
+        return sentinel;
+    }
+
     /**
      * Get build info from Artifactory.
      *
@@ -241,6 +564,55 @@ public class IntegrationTestsHelper implements AutoCloseable {
         return buildInfoClient.getBuildInfo(buildName, buildNumber);
     }
 
+    /**
+     * Publish a synthetic build-info to Artifactory, for tests of build-centric APIs.
+     * Each module contains a single artifact and a single dependency with checksums derived from their names.
+     *
+     * @param buildName       - Build name
+     * @param buildNumber     - Build number
+     * @param modulesCount    - Number of modules in the build-info
+     * @param promotionStatus - Promotion status to set on the published build, or null to skip the promotion
+     * @return the published build-info
+     * @throws IOException if failed to publish or promote the build-info
+     */
+    public Build seedBuildInfo(String buildName, String buildNumber, int modulesCount, String promotionStatus) throws IOException {
+        BuildInfoBuilder buildInfoBuilder = new BuildInfoBuilder(buildName)
+                .number(buildNumber)
+                .started(new SimpleDateFormat(Build.STARTED_FORMAT).format(new Date()));
+        for (int i = 0; i < modulesCount; i++) {
+            String artifactName = "module-" + i + "-" + buildNumber + ".jar";
+            String dependencyId = "jfrog-rt-tests:dependency-" + i + ":1.0.0";
+            buildInfoBuilder.addModule(new ModuleBuilder()
+                    .id("jfrog-rt-tests:module-" + i + ":" + buildNumber)
+                    .addArtifact(new ArtifactBuilder(artifactName)
+                            .type("jar")
+                            .sha1(DigestUtils.sha1Hex(artifactName))
+                            .md5(DigestUtils.md5Hex(artifactName))
+                            .build())
+                    .addDependency(new DependencyBuilder()
+                            .id(dependencyId)
+                            .type("jar")
+                            .sha1(DigestUtils.sha1Hex(dependencyId))
+                            .md5(DigestUtils.md5Hex(dependencyId))
+                            .build())
+                    .build());
+        }
+        Build buildInfo = buildInfoBuilder.build();
+        buildInfoClient.sendBuildInfo(buildInfo);
+        if (StringUtils.isNotBlank(promotionStatus)) {
+            ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                    .method(ArtifactoryRequest.Method.POST)
+                    .requestType(ArtifactoryRequest.ContentType.JSON)
+                    .apiUrl("api/build/promote/" + encodePathSegment(buildName) + "/" + encodePathSegment(buildNumber))
+                    .requestBody(OBJECT_MAPPER.writeValueAsString(Collections.singletonMap("status", promotionStatus))));
+            if (!response.isSuccessResponse()) {
+                throw new IOException("Failed to promote build " + buildName + "/" + buildNumber + ": " + response.getStatusLine());
+            }
+        }
+        log.info("Build " + buildName + "/" + buildNumber + " published");
+        return buildInfo;
+    }
+
     /**
      * Assert that secret environment variables haven't been published.
      *
@@ -302,6 +674,70 @@ public class IntegrationTestsHelper implements AutoCloseable {
         assertEquals(expectedArtifacts, actualArtifacts);
     }
 
+    /**
+     * Run an AQL query in Artifactory.
+     *
+     * @param aqlQuery - The AQL query, for example: items.find({"repo":"my-repo"})
+     * @return the results array of the query
+     * @throws IOException if failed to run the query
+     */
+    public JsonNode searchAql(String aqlQuery) throws IOException {
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.POST)
+                .requestType(ArtifactoryRequest.ContentType.TEXT)
+                .apiUrl("api/search/aql")
+                .requestBody(aqlQuery));
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to run AQL query: " + response.getStatusLine());
+        }
+        return OBJECT_MAPPER.readTree(response.getRawBody()).path("results");
+    }
+
+    /**
+     * Assert that an AQL query returns the expected number of results.
+     * The query is retried until the timeout expires, to allow Artifactory to index recently deployed items.
+     *
+     * @param aqlQuery       - The AQL query
+     * @param expectedCount  - Expected number of results
+     * @param timeoutSeconds - Time to wait for the expected results
+     * @throws IOException          if failed to run the query
+     * @throws InterruptedException if interrupted while waiting
+     */
+    public void assertAqlResultsCount(String aqlQuery, int expectedCount, long timeoutSeconds) throws IOException, InterruptedException {
+        AtomicInteger resultsCount = new AtomicInteger();
+        boolean reached = waitFor(() -> {
+            resultsCount.set(searchAql(aqlQuery).size());
+            return resultsCount.get() == expectedCount;
+        }, timeoutSeconds);
+        assertTrue("Expected " + expectedCount + " AQL results, but found " + resultsCount.get(), reached);
+    }
+
+    /**
+     * Assert that the values of a field in the AQL query results are equal to the expected values.
+     * The query is retried until the timeout expires, to allow Artifactory to index recently deployed items.
+     *
+     * @param aqlQuery       - The AQL query
+     * @param field          - The result field to check, for example: name
+     * @param expectedValues - Expected values of the field
+     * @param timeoutSeconds - Time to wait for the expected results
+     * @throws IOException          if failed to run the query
+     * @throws InterruptedException if interrupted while waiting
+     */
+    public void assertAqlResultsField(String aqlQuery, String field, Set<String> expectedValues, long timeoutSeconds) throws IOException, InterruptedException {
+        AtomicReference<Set<String>> actualValues = new AtomicReference<>();
+        boolean reached = waitFor(() -> {
+            actualValues.set(getFieldValues(searchAql(aqlQuery), field));
+            return expectedValues.equals(actualValues.get());
+        }, timeoutSeconds);
+        assertTrue("Expected AQL results " + field + " values " + expectedValues + ", but found " + actualValues.get(), reached);
+    }
+
+    private Set<String> getFieldValues(JsonNode results, String field) {
+        return StreamSupport.stream(results.spliterator(), false)
+                .map(result -> result.path(field).asText())
+                .collect(Collectors.toSet());
+    }
+
     /**
      * Get module from the build-info object and assert its existence.
      *
@@ -344,18 +780,132 @@ public class IntegrationTestsHelper implements AutoCloseable {
      * Delete build in Artifactory.
      *
      * @param buildName - Build name to delete
-     * @throws IOException if failed to execute the DELETE request or in a failure during encoding the build name
+     * @throws IOException if failed to delete the build or in a failure during encoding the build name
      */
     public void deleteBuild(String buildName) throws IOException {
-        artifactoryClient.restCall(new ArtifactoryRequestImpl()
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
                 .method(ArtifactoryRequest.Method.DELETE)
-                .apiUrl("api/build/" + encodeBuildName(buildName))
+                .apiUrl("api/build/" + encodePathSegment(buildName))
                 .addQueryParam("deleteAll", "1")
                 .addQueryParam("artifacts", "1"));
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to delete build " + buildName + ": " + response.getStatusLine());
+        }
+    }
+
+    /**
+     * Run garbage collection in Artifactory.
+     *
+     * @throws IOException if failed to run the garbage collection
+     */
+    public void runGarbageCollection() throws IOException {
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.POST)
+                .apiUrl("api/system/storage/gc"));
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to run garbage collection: " + response.getStatusLine());
+        }
+    }
+
+    /**
+     * Trigger a recalculation of the storage summary. The calculation runs asynchronously in Artifactory.
+     *
+     * @throws IOException if failed to trigger the calculation
+     */
+    public void calculateStorageSummary() throws IOException {
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.POST)
+                .apiUrl("api/storageinfo/calculate"));
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to calculate storage summary: " + response.getStatusLine());
+        }
+    }
+
+    /**
+     * Get the storage summary from Artifactory.
+     *
+     * @return the storage summary, as returned from the storage info REST API
+     * @throws IOException if failed to get the storage summary
+     */
+    public JsonNode getStorageSummary() throws IOException {
+        ArtifactoryResponse response = artifactoryClient.restCall(new ArtifactoryRequestImpl()
+                .method(ArtifactoryRequest.Method.GET)
+                .apiUrl("api/storageinfo"));
+        if (!response.isSuccessResponse()) {
+            throw new IOException("Failed to get storage summary: " + response.getStatusLine());
+        }
+        return OBJECT_MAPPER.readTree(response.getRawBody());
+    }
+
+    /**
+     * Get the number of binaries in the filestore.
+     *
+     * @return the number of binaries in the filestore
+     * @throws IOException if failed to get the storage summary
+     */
+    public long getBinariesCount() throws IOException {
+        String binariesCount = getStorageSummary().path("binariesSummary").path("binariesCount").asText();
+        return Long.parseLong(StringUtils.remove(binariesCount, ','));
+    }
+
+    /**
+     * Run garbage collection and assert that the number of binaries in the filestore reaches the expected number.
+     *
+     * @param expectedBinariesCount - Expected number of binaries in the filestore
+     * @param timeoutSeconds        - Time to wait for the expected number of binaries
+     * @throws IOException          if failed to run the garbage collection
+     * @throws InterruptedException if interrupted while waiting
+     */
+    public void assertBinariesCountAfterGarbageCollection(long expectedBinariesCount, long timeoutSeconds) throws IOException, InterruptedException {
+        runGarbageCollection();
+        AtomicLong binariesCount = new AtomicLong();
+        AtomicLong lastCalculation = new AtomicLong();
+        boolean reached = waitFor(() -> {
+            // The calculation runs asynchronously, so avoid queueing a new calculation on every poll
+            if (System.currentTimeMillis() - lastCalculation.get() >= STORAGE_CALCULATION_INTERVAL_MILLIS) {
+                calculateStorageSummary();
+                lastCalculation.set(System.currentTimeMillis());
+            }
+            binariesCount.set(getBinariesCount());
+            return binariesCount.get() == expectedBinariesCount;
+        }, timeoutSeconds);
+        assertTrue("Expected " + expectedBinariesCount + " binaries after garbage collection, but found " + binariesCount.get(), reached);
+    }
+
+    /**
+     * A condition to poll in {@link #waitFor(Condition, long)}.
+     */
+    @FunctionalInterface
+    public interface Condition {
+        /**
+         * @return true if the condition is met
+         * @throws IOException if failed to check the condition
+         */
+        boolean isMet() throws IOException;
+    }
+
+    /**
+     * Poll a condition until it is met or until the timeout expires.
+     *
+     * @param condition      - The condition to poll
+     * @param timeoutSeconds - Time to wait for the condition
+     * @return true if the condition was met before the timeout expired
+     * @throws IOException          if failed to check the condition
+     * @throws InterruptedException if interrupted while waiting
+     */
+    public static boolean waitFor(Condition condition, long timeoutSeconds) throws IOException, InterruptedException {
+        long deadline = System.currentTimeMillis() + TimeUnit.SECONDS.toMillis(timeoutSeconds);
+        while (!condition.isMet()) {
+            if (System.currentTimeMillis() >= deadline) {
+                return false;
+            }
+            Thread.sleep(POLLING_INTERVAL_MILLIS);
+        }
+        return true;
     }
 
-    private String encodeBuildName(String buildName) throws UnsupportedEncodingException {
-        return URLEncoder.encode(buildName, "UTF-8").replace("+", "%20");
+    private String encodePathSegment(String pathSegment) throws UnsupportedEncodingException {
+        return URLEncoder.encode(pathSegment, "UTF-8").replace("+", "%20");
     }
 
     @Override
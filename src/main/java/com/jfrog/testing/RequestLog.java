@@ -0,0 +1,210 @@
+package com.jfrog.testing;
+
+import java.io.BufferedReader;
+import java.io.IOException;
+import java.io.InputStreamReader;
+import java.nio.ByteBuffer;
+import java.nio.channels.Channels;
+import java.nio.channels.FileChannel;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.StandardOpenOption;
+import java.nio.file.attribute.BasicFileAttributes;
+import java.util.Arrays;
+import java.util.Collections;
+import java.util.List;
+import java.util.Objects;
+import java.util.regex.Pattern;
+import java.util.stream.Collectors;
+
+import static org.junit.Assert.assertFalse;
+import static org.junit.Assert.assertTrue;
+
+/**
+ * Read the requests logged in the Artifactory request log, to assert that specific requests did or did not reach the server.
+ * Expects the Artifactory 7 request log format:
+ * date|trace-id|remote-address|username|method|url|status|request-content-length|response-content-length|duration|user-agent
+ */
+@SuppressWarnings("unused")
+public class RequestLog {
+    private static final int USERNAME_INDEX = 3;
+    private static final int METHOD_INDEX = 4;
+    private static final int URL_INDEX = 5;
+    private static final int STATUS_INDEX = 6;
+    private static final int MIN_FIELDS = 7;
+    // Number of bytes from the beginning of the request log, compared to detect log rotation
+    private static final int HEAD_LENGTH = 1024;
+
+    private final Path requestLogPath;
+    private Object fileId;
+    private byte[] head;
+    private long offset;
+
+    /**
+     * @param requestLogPath - Path to artifactory-request.log
+     * @throws IOException if failed to read the request log attributes
+     */
+    public RequestLog(Path requestLogPath) throws IOException {
+        this.requestLogPath = requestLogPath;
+        mark();
+    }
+
+    /**
+     * Ignore the requests logged until now.
+     *
+     * @throws IOException if failed to read the request log attributes
+     */
+    public void mark() throws IOException {
+        if (!Files.exists(requestLogPath)) {
+            fileId = null;
+            head = new byte[0];
+            offset = 0;
+            return;
+        }
+        fileId = getFileId();
+        head = readHead(HEAD_LENGTH);
+        offset = Files.size(requestLogPath);
+    }
+
+    /**
+     * Get the requests logged since the last mark.
+     *
+     * @return the requests logged since the last mark
+     * @throws IOException if failed to read the request log
+     */
+    public List<Request> getRequests() throws IOException {
+        if (!Files.exists(requestLogPath)) {
+            return Collections.emptyList();
+        }
+        // Read the whole file if the request log was rotated since the last mark
+        long start = isRotated() ? 0 : offset;
+        try (FileChannel channel = FileChannel.open(requestLogPath, StandardOpenOption.READ);
+             BufferedReader reader = new BufferedReader(new InputStreamReader(Channels.newInputStream(channel.position(start)), StandardCharsets.UTF_8))) {
+            return reader.lines()
+                    .map(line -> line.split("\\|"))
+                    .filter(fields -> fields.length >= MIN_FIELDS)
+                    .map(Request::new)
+                    .collect(Collectors.toList());
+        }
+    }
+
+    /**
+     * Assert that a matching request was logged since the last mark.
+     * The request log is polled until the timeout expires, since Artifactory writes it asynchronously.
+     *
+     * @param method         - Request method
+     * @param urlRegex       - Regular expression of the request URL, for example: /api/npm/.*
+     * @param username       - The user who sent the request, or null to match any user
+     * @param timeoutSeconds - Time to wait for the request to be logged
+     * @throws IOException          if failed to read the request log
+     * @throws InterruptedException if interrupted while waiting
+     */
+    public void assertRequestReceived(String method, String urlRegex, String username, long timeoutSeconds) throws IOException, InterruptedException {
+        boolean received = IntegrationTestsHelper.waitFor(() -> containsRequest(method, urlRegex, username), timeoutSeconds);
+        assertTrue("Request " + method + " " + urlRegex + " was not received", received);
+    }
+
+    /**
+     * Assert that no matching request was logged since the last mark.
+     * Since Artifactory writes the request log asynchronously, a sentinel request is sent first, and the assertion runs only
+     * after the sentinel request is logged. Requests that reached the server before the sentinel request are logged by then.
+     *
+     * @param helper         - Integration tests helper, used to send the sentinel request
+     * @param method         - Request method
+     * @param urlRegex       - Regular expression of the request URL, for example: /api/npm/.*
+     * @param username       - The user who sent the request, or null to match any user
+     * @param timeoutSeconds - Time to wait for the sentinel request to be logged
+     * @throws IOException          if failed to send the sentinel request or to read the request log
+     * @throws InterruptedException if interrupted while waiting
+     */
+    public void assertRequestNotReceived(IntegrationTestsHelper helper, String method, String urlRegex, String username, long timeoutSeconds) throws IOException, InterruptedException {
+        String sentinel = helper.sendSentinelRequest();
+        assertRequestReceived("GET", ".*/" + Pattern.quote(sentinel), null, timeoutSeconds);
+        assertFalse("Request " + method + " " + urlRegex + " was received", containsRequest(method, urlRegex, username));
+    }
+
+    /**
+     * Return true if the request log was replaced since the last mark.
+     * The file identifier alone is not enough where file keys are not supported, since NTFS may reuse the creation time of
+     * a file recreated with the same name. Therefore, the beginning of the file is compared too.
+     *
+     * @return true if the request log was replaced since the last mark
+     * @throws IOException if failed to read the request log
+     */
+    private boolean isRotated() throws IOException {
+        return !getFileId().equals(fileId) || Files.size(requestLogPath) < offset || !Arrays.equals(head, readHead(head.length));
+    }
+
+    /**
+     * Read the beginning of the request log.
+     *
+     * @param length - Maximum number of bytes to read
+     * @return up to length bytes from the beginning of the request log
+     * @throws IOException if failed to read the request log
+     */
+    private byte[] readHead(int length) throws IOException {
+        ByteBuffer buffer = ByteBuffer.allocate(length);
+        try (FileChannel channel = FileChannel.open(requestLogPath, StandardOpenOption.READ)) {
+            while (buffer.hasRemaining() && channel.read(buffer) != -1) {
+                // Read until the buffer is full or the end of the file is reached
+            }
+        }
+        return Arrays.copyOf(buffer.array(), buffer.position());
+    }
+
+    /**
+     * Get an identifier of the request log file, which changes when the file is replaced by log rotation.
+     * Use the file key where the filesystem supports it, or the creation time otherwise.
+     *
+     * @return the request log file identifier
+     * @throws IOException if failed to read the request log attributes
+     */
+    private Object getFileId() throws IOException {
+        BasicFileAttributes attributes = Files.readAttributes(requestLogPath, BasicFileAttributes.class);
+        return attributes.fileKey() != null ? attributes.fileKey() : attributes.creationTime();
+    }
+
+    private boolean containsRequest(String method, String urlRegex, String username) throws IOException {
+        Pattern urlPattern = Pattern.compile(urlRegex);
+        return getRequests().stream()
+                .filter(request -> method.equalsIgnoreCase(request.getMethod()))
+                .filter(request -> urlPattern.matcher(request.getUrl()).matches())
+                .anyMatch(request -> username == null || Objects.equals(username, request.getUsername()));
+    }
+
+    public static class Request {
+        private final String username;
+        private final String method;
+        private final String url;
+        private final String status;
+
+        private Request(String[] fields) {
+            this.username = fields[USERNAME_INDEX];
+            this.method = fields[METHOD_INDEX];
+            this.url = fields[URL_INDEX];
+            this.status = fields[STATUS_INDEX];
+        }
+
+        public String getUsername() {
+            return username;
+        }
+
+        public String getMethod() {
+            return method;
+        }
+
+        public String getUrl() {
+            return url;
+        }
+
+        public String getStatus() {
+            return status;
+        }
+
+        @Override
+        public String toString() {
+            return method + " " + url + " " + status;
+        }
+    }
+}
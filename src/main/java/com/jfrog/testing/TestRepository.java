@@ -5,6 +5,9 @@ package com.jfrog.testing;
  */
 @SuppressWarnings("unused")
 public class TestRepository {
+    // The prefix of all test repository names
+    public static final String REPO_PREFIX = "jfrog-rt-tests-";
+
     public enum RepoType {
         LOCAL,
         REMOTE,
@@ -15,7 +18,7 @@ public class TestRepository {
     private final RepoType repoType;
 
     public TestRepository(String repoName, RepoType repoType) {
-        this.repoName = "jfrog-rt-tests-" + repoName;
+        this.repoName = REPO_PREFIX + repoName;
         this.repoType = repoType;
     }
 
@@ -0,0 +1,54 @@
+package com.jfrog.testing;
+
+import org.junit.Rule;
+import org.junit.Test;
+import org.junit.rules.TemporaryFolder;
+
+import java.io.IOException;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.util.Arrays;
+
+import static org.junit.Assert.*;
+
+public class IntegrationTestsHelperTest {
+    @Rule
+    public TemporaryFolder tempFolder = new TemporaryFolder();
+
+    @Test
+    public void testCreateLargeFileSize() throws IOException {
+        // Not a multiple of the 1MB write chunks
+        long size = 2 * 1024 * 1024 + 123;
+        Path target = tempFolder.getRoot().toPath().resolve("large.bin");
+        IntegrationTestsHelper.createLargeFile(target, size, false, 1);
+        assertEquals(size, Files.size(target));
+    }
+
+    @Test
+    public void testCreateLargeFileSeed() throws IOException {
+        Path first = tempFolder.getRoot().toPath().resolve("first.bin");
+        Path second = tempFolder.getRoot().toPath().resolve("second.bin");
+        Path otherSeed = tempFolder.getRoot().toPath().resolve("other-seed.bin");
+        IntegrationTestsHelper.createLargeFile(first, 4096, false, 1);
+        IntegrationTestsHelper.createLargeFile(second, 4096, false, 1);
+        IntegrationTestsHelper.createLargeFile(otherSeed, 4096, false, 2);
+        assertArrayEquals(Files.readAllBytes(first), Files.readAllBytes(second));
+        assertFalse(Arrays.equals(Files.readAllBytes(first), Files.readAllBytes(otherSeed)));
+    }
+
+    @Test
+    public void testCreateSparseFileTruncatesExistingFile() throws IOException {
+        Path target = tempFolder.getRoot().toPath().resolve("sparse.bin");
+        byte[] existingContent = new byte[4096];
+        Arrays.fill(existingContent, (byte) 0x7f);
+        Files.write(target, existingContent);
+
+        IntegrationTestsHelper.createLargeFile(target, 100, true, 0);
+        assertArrayEquals(new byte[100], Files.readAllBytes(target));
+    }
+
+    @Test(expected = IllegalArgumentException.class)
+    public void testCreateLargeFileNegativeSize() throws IOException {
+        IntegrationTestsHelper.createLargeFile(tempFolder.getRoot().toPath().resolve("negative.bin"), -1, false, 0);
+    }
+}
@@ -0,0 +1,102 @@
+package com.jfrog.testing;
+
+import org.junit.Before;
+import org.junit.Rule;
+import org.junit.Test;
+import org.junit.rules.TemporaryFolder;
+
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Path;
+import java.nio.file.StandardOpenOption;
+import java.util.Arrays;
+import java.util.List;
+import java.util.stream.Collectors;
+
+import static org.junit.Assert.assertEquals;
+import static org.junit.Assert.assertTrue;
+
+public class RequestLogTest {
+    @Rule
+    public TemporaryFolder tempFolder = new TemporaryFolder();
+
+    private Path requestLogPath;
+
+    @Before
+    public void setUp() {
+        requestLogPath = tempFolder.getRoot().toPath().resolve("artifactory-request.log");
+    }
+
+    @Test
+    public void testLogCreatedAfterMark() throws IOException {
+        RequestLog requestLog = new RequestLog(requestLogPath);
+        assertTrue(requestLog.getRequests().isEmpty());
+
+        append(line(1, "admin", "GET", "/api/system/ping", "200"));
+        List<RequestLog.Request> requests = requestLog.getRequests();
+        assertEquals(1, requests.size());
+        RequestLog.Request request = requests.get(0);
+        assertEquals("admin", request.getUsername());
+        assertEquals("GET", request.getMethod());
+        assertEquals("/api/system/ping", request.getUrl());
+        assertEquals("200", request.getStatus());
+    }
+
+    @Test
+    public void testAppendAfterMark() throws IOException {
+        append(line(1, "admin", "GET", "/api/system/ping", "200"));
+        RequestLog requestLog = new RequestLog(requestLogPath);
+        assertTrue(requestLog.getRequests().isEmpty());
+
+        append(line(2, "deployer", "PUT", "/generic-local/a.bin", "201"),
+                line(3, "reader", "GET", "/generic-local/a.bin", "200"));
+        assertEquals(Arrays.asList("PUT /generic-local/a.bin 201", "GET /generic-local/a.bin 200"), toStrings(requestLog.getRequests()));
+
+        requestLog.mark();
+        assertTrue(requestLog.getRequests().isEmpty());
+    }
+
+    @Test
+    public void testRotationToLongerFile() throws IOException {
+        append(line(1, "admin", "GET", "/api/system/ping", "200"));
+        RequestLog requestLog = new RequestLog(requestLogPath);
+
+        // Rotate the log, and grow the new file past the old offset
+        Files.move(requestLogPath, requestLogPath.resolveSibling("artifactory-request.1.log"));
+        append(line(2, "admin", "GET", "/api/repositories", "200"),
+                line(3, "admin", "DELETE", "/api/repositories/generic-local", "200"));
+        assertEquals(Arrays.asList("GET /api/repositories 200", "DELETE /api/repositories/generic-local 200"), toStrings(requestLog.getRequests()));
+    }
+
+    @Test
+    public void testRewrittenInPlaceToLongerFile() throws IOException {
+        append(line(1, "admin", "GET", "/api/system/ping", "200"));
+        RequestLog requestLog = new RequestLog(requestLogPath);
+
+        // Replace the content of the same file, where the file identifier doesn't change
+        Files.write(requestLogPath, Arrays.asList(line(2, "admin", "GET", "/api/repositories", "200"),
+                line(3, "admin", "GET", "/api/build", "200")), StandardCharsets.UTF_8, StandardOpenOption.TRUNCATE_EXISTING);
+        assertEquals(Arrays.asList("GET /api/repositories 200", "GET /api/build 200"), toStrings(requestLog.getRequests()));
+    }
+
+    @Test
+    public void testSkipLinesWithMissingFields() throws IOException {
+        RequestLog requestLog = new RequestLog(requestLogPath);
+        append("", "Not a request line", "2024-01-01T00:00:00.000Z|trace|127.0.0.1|admin|GET|/api/system/ping",
+                line(1, "admin", "GET", "/api/system/ping", "200"));
+        assertEquals(Arrays.asList("GET /api/system/ping 200"), toStrings(requestLog.getRequests()));
+    }
+
+    private void append(String... lines) throws IOException {
+        Files.write(requestLogPath, Arrays.asList(lines), StandardCharsets.UTF_8, StandardOpenOption.CREATE, StandardOpenOption.APPEND);
+    }
+
+    private static String line(int second, String username, String method, String url, String status) {
+        return String.format("2024-01-01T00:00:%02d.000Z|trace-%d|127.0.0.1|%s|%s|%s|%s|-1|0|1|curl/8.0", second, second, username, method, url, status);
+    }
+
+    private static List<String> toStrings(List<RequestLog.Request> requests) {
+        return requests.stream().map(RequestLog.Request::toString).collect(Collectors.toList());
+    }
+}